@@ -7,8 +7,10 @@
 package s3control
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/s3control"
@@ -19,6 +21,13 @@ import (
 	"github.com/hashicorp/terraform-provider-aws/names"
 )
 
+// s3ControlSweepRateLimiter is shared across all s3control sweepers running
+// concurrently within a region so they don't collectively exceed the S3
+// Control API budget.
+var s3ControlSweepRateLimiter = sweep.NewRateLimiter(20)
+
+const s3ControlSweepMaxConcurrency = 10
+
 func init() {
 	resource.AddTestSweepers("aws_s3_access_point", &resource.Sweeper{
 		Name: "aws_s3_access_point",
@@ -55,6 +64,14 @@ func sweepAccessPoints(region string) error {
 	input := &s3control.ListAccessPointsInput{
 		AccountId: aws.String(accountID),
 	}
+	filter, err := sweep.ResolveFilter()
+	if err != nil {
+		return err
+	}
+	dryRunOpts, err := sweep.DryRunOptionsFromFlags()
+	if err != nil {
+		return err
+	}
 	sweepResources := make([]sweep.Sweepable, 0)
 	var sweeperErrs *multierror.Error
 
@@ -81,11 +98,26 @@ func sweepAccessPoints(region string) error {
 				d.SetId(id)
 			}
 
-			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client))
+			name := aws.ToString(v.Name)
+			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client,
+				sweep.WithIdentifier(name),
+				sweep.WithARN(aws.ToString(v.AccessPointArn)),
+				sweep.WithTags(accessPointTags(ctx, conn, accountID, name)),
+			))
 		}
 	}
 
-	err = sweep.SweepOrchestrator(ctx, sweepResources)
+	orchestratorOpts := append([]sweep.OptionsFunc{
+		sweep.WithMaxConcurrency(s3ControlSweepMaxConcurrency),
+		sweep.WithRateLimiter(s3ControlSweepRateLimiter),
+		sweep.WithReport(nil),
+		sweep.WithFilter(filter),
+		sweep.WithResourceType("aws_s3_access_point"),
+		sweep.WithRegion(region),
+		sweep.WithAccountID(accountID),
+	}, dryRunOpts...)
+
+	err = sweep.SweepOrchestratorWithContext(ctx, sweepResources, orchestratorOpts...)
 
 	if err != nil {
 		sweeperErrs = multierror.Append(sweeperErrs, fmt.Errorf("error sweeping S3 Access Points (%s): %w", region, err))
@@ -94,6 +126,50 @@ func sweepAccessPoints(region string) error {
 	return sweeperErrs.ErrorOrNil()
 }
 
+// accessPointTags returns a sweep.TagsFunc that looks up an access point's
+// tags on demand via DescribeAccessPoint, for -sweep-tag-filter matching.
+func accessPointTags(ctx context.Context, conn *s3control.Client, accountID, name string) sweep.TagsFunc {
+	return func(ctx context.Context) (map[string]string, error) {
+		out, err := conn.DescribeAccessPoint(ctx, &s3control.DescribeAccessPointInput{
+			AccountId: aws.String(accountID),
+			Name:      aws.String(name),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tags := make(map[string]string, len(out.Tags))
+		for _, t := range out.Tags {
+			tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+		}
+		return tags, nil
+	}
+}
+
+// unsupportedTagFilter returns a sweep.TagsFunc for resource types this
+// package has no tag lookup for. It fails loudly rather than returning
+// (nil, nil), which would make -sweep-tag-filter silently exclude every
+// resource of that type instead of erroring.
+func unsupportedTagFilter(resourceType string) sweep.TagsFunc {
+	return func(ctx context.Context) (map[string]string, error) {
+		return nil, fmt.Errorf("-sweep-tag-filter is not supported for %s: no tag lookup is implemented for it", resourceType)
+	}
+}
+
+// partitionForRegion returns the ARN partition a region belongs to. It's
+// deliberately minimal (not a full region-to-partition lookup) since it's
+// only used to construct best-effort ARNs for dry-run manifest entries.
+func partitionForRegion(region string) string {
+	switch {
+	case strings.HasPrefix(region, "cn-"):
+		return "aws-cn"
+	case strings.HasPrefix(region, "us-gov-"):
+		return "aws-us-gov"
+	default:
+		return "aws"
+	}
+}
+
 func sweepMultiRegionAccessPoints(region string) error {
 	ctx := sweep.Context(region)
 	if region != names.USWest2RegionID {
@@ -109,6 +185,14 @@ func sweepMultiRegionAccessPoints(region string) error {
 	input := &s3control.ListMultiRegionAccessPointsInput{
 		AccountId: aws.String(accountID),
 	}
+	filter, err := sweep.ResolveFilter()
+	if err != nil {
+		return err
+	}
+	dryRunOpts, err := sweep.DryRunOptionsFromFlags()
+	if err != nil {
+		return err
+	}
 	sweepResources := make([]sweep.Sweepable, 0)
 
 	pages := s3control.NewListMultiRegionAccessPointsPaginator(conn, input)
@@ -127,13 +211,31 @@ func sweepMultiRegionAccessPoints(region string) error {
 		for _, v := range page.AccessPoints {
 			r := resourceMultiRegionAccessPoint()
 			d := r.Data(nil)
-			d.SetId(MultiRegionAccessPointCreateResourceID(accountID, aws.ToString(v.Name)))
-
-			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client))
+			name := aws.ToString(v.Name)
+			d.SetId(MultiRegionAccessPointCreateResourceID(accountID, name))
+
+			// Multi-Region Access Points are global, not regional, so their
+			// ARN has no region component and is keyed by alias, not name.
+			arn := fmt.Sprintf("arn:%s:s3::%s:accesspoint/%s", partitionForRegion(region), accountID, aws.ToString(v.Alias))
+			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client,
+				sweep.WithIdentifier(name),
+				sweep.WithARN(arn),
+				sweep.WithTags(unsupportedTagFilter("aws_s3control_multi_region_access_point")),
+			))
 		}
 	}
 
-	err = sweep.SweepOrchestrator(ctx, sweepResources)
+	orchestratorOpts := append([]sweep.OptionsFunc{
+		sweep.WithMaxConcurrency(s3ControlSweepMaxConcurrency),
+		sweep.WithRateLimiter(s3ControlSweepRateLimiter),
+		sweep.WithReport(nil),
+		sweep.WithFilter(filter),
+		sweep.WithResourceType("aws_s3control_multi_region_access_point"),
+		sweep.WithRegion(region),
+		sweep.WithAccountID(accountID),
+	}, dryRunOpts...)
+
+	err = sweep.SweepOrchestratorWithContext(ctx, sweepResources, orchestratorOpts...)
 
 	if err != nil {
 		return fmt.Errorf("error sweeping S3 Multi-Region Access Points (%s): %w", region, err)
@@ -153,6 +255,14 @@ func sweepObjectLambdaAccessPoints(region string) error {
 	input := &s3control.ListAccessPointsForObjectLambdaInput{
 		AccountId: aws.String(accountID),
 	}
+	filter, err := sweep.ResolveFilter()
+	if err != nil {
+		return err
+	}
+	dryRunOpts, err := sweep.DryRunOptionsFromFlags()
+	if err != nil {
+		return err
+	}
 	sweepResources := make([]sweep.Sweepable, 0)
 
 	pages := s3control.NewListAccessPointsForObjectLambdaPaginator(conn, input)
@@ -171,13 +281,29 @@ func sweepObjectLambdaAccessPoints(region string) error {
 		for _, v := range page.ObjectLambdaAccessPointList {
 			r := resourceObjectLambdaAccessPoint()
 			d := r.Data(nil)
-			d.SetId(ObjectLambdaAccessPointCreateResourceID(accountID, aws.ToString(v.Name)))
-
-			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client))
+			name := aws.ToString(v.Name)
+			d.SetId(ObjectLambdaAccessPointCreateResourceID(accountID, name))
+
+			arn := fmt.Sprintf("arn:%s:s3-object-lambda:%s:%s:accesspoint/%s", partitionForRegion(region), region, accountID, name)
+			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client,
+				sweep.WithIdentifier(name),
+				sweep.WithARN(arn),
+				sweep.WithTags(unsupportedTagFilter("aws_s3control_object_lambda_access_point")),
+			))
 		}
 	}
 
-	err = sweep.SweepOrchestrator(ctx, sweepResources)
+	orchestratorOpts := append([]sweep.OptionsFunc{
+		sweep.WithMaxConcurrency(s3ControlSweepMaxConcurrency),
+		sweep.WithRateLimiter(s3ControlSweepRateLimiter),
+		sweep.WithReport(nil),
+		sweep.WithFilter(filter),
+		sweep.WithResourceType("aws_s3control_object_lambda_access_point"),
+		sweep.WithRegion(region),
+		sweep.WithAccountID(accountID),
+	}, dryRunOpts...)
+
+	err = sweep.SweepOrchestratorWithContext(ctx, sweepResources, orchestratorOpts...)
 
 	if err != nil {
 		return fmt.Errorf("error sweeping S3 Object Lambda Access Points (%s): %w", region, err)
@@ -201,6 +327,14 @@ func sweepStorageLensConfigurations(region string) error {
 	input := &s3control.ListStorageLensConfigurationsInput{
 		AccountId: aws.String(accountID),
 	}
+	filter, err := sweep.ResolveFilter()
+	if err != nil {
+		return err
+	}
+	dryRunOpts, err := sweep.DryRunOptionsFromFlags()
+	if err != nil {
+		return err
+	}
 	sweepResources := make([]sweep.Sweepable, 0)
 
 	pages := s3control.NewListStorageLensConfigurationsPaginator(conn, input)
@@ -227,11 +361,26 @@ func sweepStorageLensConfigurations(region string) error {
 			d := r.Data(nil)
 			d.SetId(StorageLensConfigurationCreateResourceID(accountID, configID))
 
-			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client))
+			arn := fmt.Sprintf("arn:%s:s3:%s:%s:storage-lens/%s", partitionForRegion(region), region, accountID, configID)
+			sweepResources = append(sweepResources, sweep.NewSweepResource(r, d, client,
+				sweep.WithIdentifier(configID),
+				sweep.WithARN(arn),
+				sweep.WithTags(storageLensConfigurationTags(ctx, conn, accountID, configID)),
+			))
 		}
 	}
 
-	err = sweep.SweepOrchestrator(ctx, sweepResources)
+	orchestratorOpts := append([]sweep.OptionsFunc{
+		sweep.WithMaxConcurrency(s3ControlSweepMaxConcurrency),
+		sweep.WithRateLimiter(s3ControlSweepRateLimiter),
+		sweep.WithReport(nil),
+		sweep.WithFilter(filter),
+		sweep.WithResourceType("aws_s3control_storage_lens_configuration"),
+		sweep.WithRegion(region),
+		sweep.WithAccountID(accountID),
+	}, dryRunOpts...)
+
+	err = sweep.SweepOrchestratorWithContext(ctx, sweepResources, orchestratorOpts...)
 
 	if err != nil {
 		return fmt.Errorf("error sweeping S3 Storage Lens Configurations (%s): %w", region, err)
@@ -239,3 +388,24 @@ func sweepStorageLensConfigurations(region string) error {
 
 	return nil
 }
+
+// storageLensConfigurationTags returns a sweep.TagsFunc that looks up a
+// Storage Lens configuration's tags on demand via
+// GetStorageLensConfigurationTagging, for -sweep-tag-filter matching.
+func storageLensConfigurationTags(ctx context.Context, conn *s3control.Client, accountID, configID string) sweep.TagsFunc {
+	return func(ctx context.Context) (map[string]string, error) {
+		out, err := conn.GetStorageLensConfigurationTagging(ctx, &s3control.GetStorageLensConfigurationTaggingInput{
+			AccountId: aws.String(accountID),
+			ConfigId:  aws.String(configID),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		tags := make(map[string]string, len(out.Tags))
+		for _, t := range out.Tags {
+			tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+		}
+		return tags, nil
+	}
+}
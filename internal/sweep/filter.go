@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build sweep
+// +build sweep
+
+package sweep
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+var (
+	sweepIncludeFlag   = flag.String("sweep-include", "", "glob pattern; only sweep resources whose Identifier() matches")
+	sweepExcludeFlag   = flag.String("sweep-exclude", "", "glob pattern; never sweep resources whose Identifier() matches")
+	sweepTagFilterFlag = flag.String("sweep-tag-filter", "", "key=value; only sweep resources carrying this tag")
+)
+
+// Filter decides whether a Sweepable should be deleted, based on its
+// Identifier() and, if a tag filter is configured, its Tags().
+type Filter struct {
+	include glob.Glob
+	exclude glob.Glob
+
+	tagKey   string
+	tagValue string
+
+	// ids, when non-nil, restricts matches to exactly this set of
+	// identifiers (see FilterFromManifest) and overrides include/exclude/tag
+	// matching entirely.
+	ids map[string]struct{}
+}
+
+// FilterFromFlags builds a Filter from the -sweep-include, -sweep-exclude,
+// and -sweep-tag-filter flags. It returns a nil Filter (matching everything)
+// if none of those flags were set.
+func FilterFromFlags() (*Filter, error) {
+	return NewFilter(*sweepIncludeFlag, *sweepExcludeFlag, *sweepTagFilterFlag)
+}
+
+// NewFilter builds a Filter from glob include/exclude patterns and an
+// optional "key=value" tag filter. Empty strings mean "no constraint".
+func NewFilter(include, exclude, tagFilter string) (*Filter, error) {
+	if include == "" && exclude == "" && tagFilter == "" {
+		return nil, nil
+	}
+
+	f := &Filter{}
+
+	if include != "" {
+		g, err := glob.Compile(include)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -sweep-include pattern %q: %w", include, err)
+		}
+		f.include = g
+	}
+
+	if exclude != "" {
+		g, err := glob.Compile(exclude)
+		if err != nil {
+			return nil, fmt.Errorf("invalid -sweep-exclude pattern %q: %w", exclude, err)
+		}
+		f.exclude = g
+	}
+
+	if tagFilter != "" {
+		k, v, ok := strings.Cut(tagFilter, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid -sweep-tag-filter %q, expected key=value", tagFilter)
+		}
+		f.tagKey, f.tagValue = k, v
+	}
+
+	return f, nil
+}
+
+// HasTagConstraint reports whether f would need to fetch a Sweepable's tags
+// to evaluate a match, i.e. whether -sweep-tag-filter was configured.
+func (f *Filter) HasTagConstraint() bool {
+	return f != nil && f.tagKey != ""
+}
+
+// Match reports whether sw should be swept. Name-based include/exclude is
+// checked first, since it's free; the tag lookup only runs when a tag
+// filter is configured and the resource hasn't already been excluded by
+// name. The tags fetched for the tag-filter check (nil if no tag filter is
+// configured) are returned alongside the match result so callers, such as
+// the dry-run manifest writer, can reuse them instead of fetching twice.
+func (f *Filter) Match(ctx context.Context, sw Sweepable) (bool, map[string]string, error) {
+	if f == nil {
+		return true, nil, nil
+	}
+
+	id := sw.Identifier()
+
+	if f.ids != nil {
+		_, ok := f.ids[id]
+		return ok, nil, nil
+	}
+
+	if f.include != nil && !f.include.Match(id) {
+		return false, nil, nil
+	}
+	if f.exclude != nil && f.exclude.Match(id) {
+		return false, nil, nil
+	}
+
+	if f.tagKey == "" {
+		return true, nil, nil
+	}
+
+	tags, err := sw.Tags(ctx)
+	if err != nil {
+		return false, nil, fmt.Errorf("error fetching tags for %s: %w", id, err)
+	}
+
+	return tags[f.tagKey] == f.tagValue, tags, nil
+}
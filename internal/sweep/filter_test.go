@@ -0,0 +1,161 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build sweep
+// +build sweep
+
+package sweep
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+var errTagsUnavailable = errors.New("tags unavailable")
+
+// fakeSweepable is a minimal Sweepable test double that doesn't touch AWS.
+type fakeSweepable struct {
+	id   string
+	tags map[string]string
+	err  error
+}
+
+func (f *fakeSweepable) Delete(ctx context.Context, timeout time.Duration, optFns ...tfresource.OptionsFunc) error {
+	return nil
+}
+
+func (f *fakeSweepable) Identifier() string {
+	return f.id
+}
+
+func (f *fakeSweepable) Tags(ctx context.Context) (map[string]string, error) {
+	return f.tags, f.err
+}
+
+func mustFilter(t *testing.T, include, exclude, tagFilter string) *Filter {
+	t.Helper()
+	f, err := NewFilter(include, exclude, tagFilter)
+	if err != nil {
+		t.Fatalf("NewFilter(%q, %q, %q) returned unexpected error: %s", include, exclude, tagFilter, err)
+	}
+	return f
+}
+
+func TestFilterMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		filter    *Filter
+		sweepable *fakeSweepable
+		wantMatch bool
+		wantTags  map[string]string
+		wantErr   bool
+	}{
+		"nil filter matches everything": {
+			filter:    nil,
+			sweepable: &fakeSweepable{id: "foo"},
+			wantMatch: true,
+		},
+		"include match": {
+			filter:    mustFilter(t, "foo-*", "", ""),
+			sweepable: &fakeSweepable{id: "foo-bar"},
+			wantMatch: true,
+		},
+		"include no match": {
+			filter:    mustFilter(t, "foo-*", "", ""),
+			sweepable: &fakeSweepable{id: "baz"},
+			wantMatch: false,
+		},
+		"exclude takes priority": {
+			filter:    mustFilter(t, "foo-*", "foo-bar", ""),
+			sweepable: &fakeSweepable{id: "foo-bar"},
+			wantMatch: false,
+		},
+		"tag filter match fetches and returns tags": {
+			filter:    mustFilter(t, "", "", "env=prod"),
+			sweepable: &fakeSweepable{id: "foo", tags: map[string]string{"env": "prod"}},
+			wantMatch: true,
+			wantTags:  map[string]string{"env": "prod"},
+		},
+		"tag filter no match": {
+			filter:    mustFilter(t, "", "", "env=prod"),
+			sweepable: &fakeSweepable{id: "foo", tags: map[string]string{"env": "dev"}},
+			wantMatch: false,
+			wantTags:  map[string]string{"env": "dev"},
+		},
+		"tag filter on untagged resource": {
+			filter:    mustFilter(t, "", "", "env=prod"),
+			sweepable: &fakeSweepable{id: "foo", tags: nil},
+			wantMatch: false,
+		},
+		"tag fetch error propagates": {
+			filter:    mustFilter(t, "", "", "env=prod"),
+			sweepable: &fakeSweepable{id: "foo", err: errTagsUnavailable},
+			wantErr:   true,
+		},
+		"manifest filter restricts to exact identifiers": {
+			filter:    &Filter{ids: map[string]struct{}{"foo": {}}},
+			sweepable: &fakeSweepable{id: "foo"},
+			wantMatch: true,
+		},
+		"manifest filter excludes unlisted identifiers": {
+			filter:    &Filter{ids: map[string]struct{}{"foo": {}}},
+			sweepable: &fakeSweepable{id: "bar"},
+			wantMatch: false,
+		},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			match, tags, err := tt.filter.Match(context.Background(), tt.sweepable)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Match() error = %v, wantErr %t", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if match != tt.wantMatch {
+				t.Errorf("Match() = %t, want %t", match, tt.wantMatch)
+			}
+			if tt.wantTags != nil {
+				for k, v := range tt.wantTags {
+					if tags[k] != v {
+						t.Errorf("Match() tags[%q] = %q, want %q", k, tags[k], v)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestNewFilterInvalidTagFilter(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewFilter("", "", "no-equals-sign"); err == nil {
+		t.Fatal("NewFilter with an invalid -sweep-tag-filter value should have returned an error")
+	}
+}
+
+func TestFilterHasTagConstraint(t *testing.T) {
+	t.Parallel()
+
+	var nilFilter *Filter
+	if nilFilter.HasTagConstraint() {
+		t.Error("nil Filter should report HasTagConstraint() == false")
+	}
+
+	if mustFilter(t, "foo-*", "", "").HasTagConstraint() {
+		t.Error("Filter without a tag filter should report HasTagConstraint() == false")
+	}
+
+	if !mustFilter(t, "", "", "env=prod").HasTagConstraint() {
+		t.Error("Filter with a tag filter should report HasTagConstraint() == true")
+	}
+}
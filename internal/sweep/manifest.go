@@ -0,0 +1,142 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build sweep
+// +build sweep
+
+package sweep
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"sync"
+)
+
+var (
+	sweepDryRunFlag      = flag.Bool("sweep-dry-run", false, "enumerate sweep candidates without deleting them, emitting an NDJSON manifest")
+	sweepManifestFlag    = flag.String("sweep-manifest", "", "file to write the -sweep-dry-run manifest to (default stdout)")
+	sweepFromManifestFlg = flag.String("sweep-from-manifest", "", "only sweep resources listed in this NDJSON manifest (see -sweep-dry-run)")
+)
+
+// ManifestEntry is one line of a dry-run manifest: a single sweep candidate.
+type ManifestEntry struct {
+	Identifier   string            `json:"identifier"`
+	ARN          string            `json:"arn,omitempty"`
+	ResourceType string            `json:"resource_type,omitempty"`
+	Region       string            `json:"region,omitempty"`
+	Account      string            `json:"account,omitempty"`
+	Tags         map[string]string `json:"tags,omitempty"`
+}
+
+// manifestFile and manifestPath are shared across every DryRunOptionsFromFlags
+// call in the process. A sweep run registers dozens of sweepers against the
+// same -sweep-manifest path; each must append to the one file rather than
+// truncating it out from under the others.
+var (
+	manifestMu   sync.Mutex
+	manifestFile *os.File
+	manifestPath string
+)
+
+func openManifestFile(path string) (*os.File, error) {
+	manifestMu.Lock()
+	defer manifestMu.Unlock()
+
+	if manifestFile != nil {
+		if manifestPath != path {
+			return nil, fmt.Errorf("sweep: -sweep-manifest already opened at %q in this process, cannot also open %q", manifestPath, path)
+		}
+		return manifestFile, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("error opening sweep manifest %q: %w", path, err)
+	}
+
+	manifestFile = f
+	manifestPath = path
+	return f, nil
+}
+
+// CloseManifest closes the shared -sweep-manifest file opened on behalf of
+// DryRunOptionsFromFlags, if one was opened. Call it once after every
+// sweeper in the run has finished, e.g. from TestMain.
+func CloseManifest() error {
+	manifestMu.Lock()
+	defer manifestMu.Unlock()
+
+	if manifestFile == nil {
+		return nil
+	}
+
+	err := manifestFile.Close()
+	manifestFile = nil
+	manifestPath = ""
+	return err
+}
+
+// DryRunOptionsFromFlags returns the OptionsFuncs implied by -sweep-dry-run
+// and -sweep-manifest. It returns no options (a no-op) if -sweep-dry-run
+// wasn't passed. Every call with the same -sweep-manifest path appends to
+// the same shared file rather than truncating it.
+func DryRunOptionsFromFlags() ([]OptionsFunc, error) {
+	if !*sweepDryRunFlag {
+		return nil, nil
+	}
+
+	if *sweepManifestFlag == "" {
+		return []OptionsFunc{WithDryRun(nil)}, nil
+	}
+
+	f, err := openManifestFile(*sweepManifestFlag)
+	if err != nil {
+		return nil, err
+	}
+	return []OptionsFunc{WithDryRun(f)}, nil
+}
+
+// ResolveFilter returns the Filter implied by the sweep flags: -sweep-from-
+// manifest takes precedence over -sweep-include/-sweep-exclude/
+// -sweep-tag-filter when both are somehow set, since it names an exact,
+// already-reviewed set of resources.
+func ResolveFilter() (*Filter, error) {
+	if f, err := ManifestFilterFromFlags(); err != nil || f != nil {
+		return f, err
+	}
+	return FilterFromFlags()
+}
+
+// ManifestFilterFromFlags returns a Filter built from -sweep-from-manifest,
+// matching only the identifiers listed in that manifest. It returns a nil
+// Filter (matching everything) if the flag wasn't passed.
+func ManifestFilterFromFlags() (*Filter, error) {
+	if *sweepFromManifestFlg == "" {
+		return nil, nil
+	}
+	return FilterFromManifest(*sweepFromManifestFlg)
+}
+
+// FilterFromManifest reads a manifest written by -sweep-dry-run and returns
+// a Filter matching only the identifiers it lists.
+func FilterFromManifest(path string) (*Filter, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("error opening sweep manifest %q: %w", path, err)
+	}
+	defer f.Close()
+
+	ids := make(map[string]struct{})
+	dec := json.NewDecoder(f)
+	for dec.More() {
+		var e ManifestEntry
+		if err := dec.Decode(&e); err != nil {
+			return nil, fmt.Errorf("error parsing sweep manifest %q: %w", path, err)
+		}
+		ids[e.Identifier] = struct{}{}
+	}
+
+	return &Filter{ids: ids}, nil
+}
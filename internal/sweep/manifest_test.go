@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build sweep
+// +build sweep
+
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestFilterFromManifestRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "manifest.ndjson")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("os.Create() returned unexpected error: %s", err)
+	}
+
+	enc := json.NewEncoder(f)
+	entries := []ManifestEntry{
+		{Identifier: "keep-me", ARN: "arn:aws:s3:::keep-me", ResourceType: "aws_s3_access_point"},
+		{Identifier: "also-keep", ResourceType: "aws_s3_access_point"},
+	}
+	for _, e := range entries {
+		if err := enc.Encode(e); err != nil {
+			t.Fatalf("Encode() returned unexpected error: %s", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("Close() returned unexpected error: %s", err)
+	}
+
+	filter, err := FilterFromManifest(path)
+	if err != nil {
+		t.Fatalf("FilterFromManifest() returned unexpected error: %s", err)
+	}
+
+	for _, id := range []string{"keep-me", "also-keep"} {
+		match, _, err := filter.Match(context.Background(), &fakeSweepable{id: id})
+		if err != nil {
+			t.Fatalf("Match(%q) returned unexpected error: %s", id, err)
+		}
+		if !match {
+			t.Errorf("Match(%q) = false, want true (listed in manifest)", id)
+		}
+	}
+
+	match, _, err := filter.Match(context.Background(), &fakeSweepable{id: "not-in-manifest"})
+	if err != nil {
+		t.Fatalf("Match() returned unexpected error: %s", err)
+	}
+	if match {
+		t.Error("Match(\"not-in-manifest\") = true, want false (not listed in manifest)")
+	}
+}
+
+func TestOpenManifestFileAppendsAcrossCalls(t *testing.T) {
+	// Not t.Parallel(): openManifestFile/CloseManifest share package-level
+	// state, so this test can't safely run concurrently with another test
+	// that touches the same singleton.
+	path := filepath.Join(t.TempDir(), "manifest.ndjson")
+
+	if err := os.WriteFile(path, []byte(`{"identifier":"existing"}`+"\n"), 0o644); err != nil {
+		t.Fatalf("os.WriteFile() returned unexpected error: %s", err)
+	}
+
+	f, err := openManifestFile(path)
+	if err != nil {
+		t.Fatalf("openManifestFile() returned unexpected error: %s", err)
+	}
+	defer func() {
+		if err := CloseManifest(); err != nil {
+			t.Errorf("CloseManifest() returned unexpected error: %s", err)
+		}
+	}()
+
+	if _, err := f.WriteString(`{"identifier":"appended"}` + "\n"); err != nil {
+		t.Fatalf("WriteString() returned unexpected error: %s", err)
+	}
+	f.Sync()
+
+	// A second call for the same path must reuse the open handle, not
+	// truncate the file out from under the first caller.
+	f2, err := openManifestFile(path)
+	if err != nil {
+		t.Fatalf("openManifestFile() (second call) returned unexpected error: %s", err)
+	}
+	if f2 != f {
+		t.Error("openManifestFile() returned a different handle for the same path")
+	}
+
+	if err := CloseManifest(); err != nil {
+		t.Fatalf("CloseManifest() returned unexpected error: %s", err)
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("os.ReadFile() returned unexpected error: %s", err)
+	}
+
+	got := string(b)
+	want := "{\"identifier\":\"existing\"}\n{\"identifier\":\"appended\"}\n"
+	if got != want {
+		t.Errorf("manifest file contents = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,505 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build sweep
+// +build sweep
+
+package sweep
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"log"
+	"math/rand"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	multierror "github.com/hashicorp/go-multierror"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-provider-aws/internal/conns"
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+// Context returns the base context used for the duration of a sweep run.
+func Context(region string) context.Context {
+	return context.Background()
+}
+
+// SharedRegionalSweepClient returns the shared AWS client for region.
+func SharedRegionalSweepClient(ctx context.Context, region string) (*conns.AWSClient, error) {
+	return conns.NewAWSClient(ctx, region)
+}
+
+// Sweepable is implemented by anything the sweeper framework can delete.
+type Sweepable interface {
+	Delete(ctx context.Context, timeout time.Duration, optFns ...tfresource.OptionsFunc) error
+
+	// Identifier returns the human-readable name the sweeper framework
+	// matches against -sweep-include/-sweep-exclude filters, e.g. an
+	// access point name or a Storage Lens configuration ID.
+	Identifier() string
+
+	// Tags returns the resource's tags, fetched on demand. It is only
+	// called when a -sweep-tag-filter is configured, since fetching tags
+	// typically costs an extra describe/get API call per resource.
+	Tags(ctx context.Context) (map[string]string, error)
+}
+
+// TagsFunc lazily fetches a resource's tags, e.g. by calling
+// DescribeAccessPoint or GetStorageLensConfigurationTagging.
+type TagsFunc func(ctx context.Context) (map[string]string, error)
+
+// arnProvider is implemented by Sweepables that know their own ARN. It's
+// kept separate from Sweepable, rather than added to it as a required
+// method, so existing Sweepable implementations that have no ARN to offer
+// aren't forced to grow a stub method.
+type arnProvider interface {
+	ARN() string
+}
+
+// SweepResource adapts a provider resource and its resource data so it can be
+// deleted by the sweeper framework.
+type SweepResource struct {
+	d        *schema.ResourceData
+	r        *schema.Resource
+	client   *conns.AWSClient
+	id       string
+	arn      string
+	tagsFunc TagsFunc
+}
+
+// SweepResourceOption mutates a SweepResource at construction time.
+type SweepResourceOption func(*SweepResource)
+
+// WithIdentifier sets the name the sweeper framework matches filters
+// against. If unset, the resource's ID (d.Id()) is used instead.
+func WithIdentifier(id string) SweepResourceOption {
+	return func(sr *SweepResource) {
+		sr.id = id
+	}
+}
+
+// WithARN records the resource's ARN for dry-run manifest entries. ARNs are
+// stable and globally unique, unlike Identifier(), which is only the name
+// the -sweep-include/-sweep-exclude filters match against.
+func WithARN(arn string) SweepResourceOption {
+	return func(sr *SweepResource) {
+		sr.arn = arn
+	}
+}
+
+// WithTags sets the function used to lazily look up the resource's tags for
+// -sweep-tag-filter matching.
+func WithTags(fn TagsFunc) SweepResourceOption {
+	return func(sr *SweepResource) {
+		sr.tagsFunc = fn
+	}
+}
+
+// NewSweepResource wraps r/d/client as a Sweepable.
+func NewSweepResource(r *schema.Resource, d *schema.ResourceData, client *conns.AWSClient, optFns ...SweepResourceOption) *SweepResource {
+	sr := &SweepResource{d: d, r: r, client: client}
+	for _, fn := range optFns {
+		fn(sr)
+	}
+	return sr
+}
+
+func (sr *SweepResource) Delete(ctx context.Context, timeout time.Duration, optFns ...tfresource.OptionsFunc) error {
+	return tfresource.DeleteResource(ctx, sr.r, sr.d, sr.client, timeout, optFns...)
+}
+
+func (sr *SweepResource) Identifier() string {
+	if sr.id != "" {
+		return sr.id
+	}
+	return sr.d.Id()
+}
+
+// ARN returns the ARN set via WithARN, or "" if none was given. It satisfies
+// the arnProvider interface the orchestrator checks for when populating
+// dry-run manifest entries.
+func (sr *SweepResource) ARN() string {
+	return sr.arn
+}
+
+func (sr *SweepResource) Tags(ctx context.Context) (map[string]string, error) {
+	if sr.tagsFunc == nil {
+		return nil, nil
+	}
+	return sr.tagsFunc(ctx)
+}
+
+// SweepOrchestrator passes its arguments to SweepOrchestratorWithContext.
+// Unless optFns include WithMaxConcurrency with a value above 1, it deletes
+// sweepResources serially, in the order given, accumulating non-fatal errors
+// rather than stopping at the first one.
+func SweepOrchestrator(ctx context.Context, sweepResources []Sweepable, optFns ...OptionsFunc) error {
+	return SweepOrchestratorWithContext(ctx, sweepResources, optFns...)
+}
+
+// SweeperReport is the structured, end-of-sweep summary emitted when
+// reporting is enabled, similar to how awsls-style clients log per-operation
+// timing.
+type SweeperReport struct {
+	StartedAt time.Time       `json:"started_at"`
+	Duration  time.Duration   `json:"duration_ns"`
+	Total     int             `json:"total"`
+	Succeeded int             `json:"succeeded"`
+	Failed    int             `json:"failed"`
+	Skipped   int             `json:"skipped"`
+	Results   []SweeperResult `json:"results"`
+}
+
+// SweeperResult captures the outcome of deleting (or skipping, or
+// dry-run-recording) a single resource.
+type SweeperResult struct {
+	Identifier   string        `json:"identifier,omitempty"`
+	Skipped      bool          `json:"skipped,omitempty"`
+	DryRun       bool          `json:"dry_run,omitempty"`
+	Duration     time.Duration `json:"duration_ns"`
+	Error        string        `json:"error,omitempty"`
+	FailureClass string        `json:"failure_class,omitempty"`
+}
+
+// Options configures SweepOrchestratorWithContext.
+type Options struct {
+	MaxConcurrency int
+	RateLimiter    *RateLimiter
+	Report         bool
+	ReportWriter   *os.File
+	Filter         *Filter
+
+	DryRun         bool
+	ManifestWriter io.Writer
+	ResourceType   string
+	Region         string
+	AccountID      string
+}
+
+// OptionsFunc mutates Options via the functional-option pattern.
+type OptionsFunc func(*Options)
+
+// WithMaxConcurrency bounds how many deletes run at once. A value <= 1 keeps
+// the original serial behavior.
+func WithMaxConcurrency(n int) OptionsFunc {
+	return func(o *Options) {
+		o.MaxConcurrency = n
+	}
+}
+
+// WithRateLimiter shares a single token bucket across sweepers running
+// against the same region so they don't collectively exceed an API budget.
+func WithRateLimiter(rl *RateLimiter) OptionsFunc {
+	return func(o *Options) {
+		o.RateLimiter = rl
+	}
+}
+
+// WithReport enables emitting a SweeperReport as JSON to w once the sweep
+// completes. A nil w defaults to os.Stdout.
+func WithReport(w *os.File) OptionsFunc {
+	return func(o *Options) {
+		o.Report = true
+		o.ReportWriter = w
+	}
+}
+
+// WithFilter applies f to sweepResources before they're deleted, skipping
+// anything that doesn't match.
+func WithFilter(f *Filter) OptionsFunc {
+	return func(o *Options) {
+		o.Filter = f
+	}
+}
+
+// WithDryRun enables dry-run mode: candidates are still enumerated and
+// filtered, but instead of being deleted each is written as one NDJSON
+// ManifestEntry to w. A nil w defaults to os.Stdout.
+func WithDryRun(w io.Writer) OptionsFunc {
+	return func(o *Options) {
+		o.DryRun = true
+		o.ManifestWriter = w
+	}
+}
+
+// WithResourceType records the Terraform resource type in dry-run manifest
+// entries, e.g. "aws_s3control_storage_lens_configuration".
+func WithResourceType(resourceType string) OptionsFunc {
+	return func(o *Options) {
+		o.ResourceType = resourceType
+	}
+}
+
+// WithRegion records the region in dry-run manifest entries.
+func WithRegion(region string) OptionsFunc {
+	return func(o *Options) {
+		o.Region = region
+	}
+}
+
+// WithAccountID records the account ID in dry-run manifest entries.
+func WithAccountID(accountID string) OptionsFunc {
+	return func(o *Options) {
+		o.AccountID = accountID
+	}
+}
+
+const (
+	defaultSweepTimeout   = 5 * time.Minute
+	defaultMaxConcurrency = 1
+	maxDeleteAttempts     = 5
+)
+
+// SweepOrchestratorWithContext deletes sweepResources with up to
+// opts.MaxConcurrency deletes in flight at once, recording per-resource
+// timing and failure classification. When WithReport is set, a
+// SweeperReport is emitted as JSON once every resource has been attempted.
+func SweepOrchestratorWithContext(ctx context.Context, sweepResources []Sweepable, optFns ...OptionsFunc) error {
+	opts := Options{MaxConcurrency: defaultMaxConcurrency}
+	for _, fn := range optFns {
+		fn(&opts)
+	}
+	if opts.MaxConcurrency < 1 {
+		opts.MaxConcurrency = defaultMaxConcurrency
+	}
+
+	start := time.Now()
+	results := make([]SweeperResult, len(sweepResources))
+
+	sem := make(chan struct{}, opts.MaxConcurrency)
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var sweeperErrs *multierror.Error
+
+	var manifestEnc *json.Encoder
+	if opts.DryRun {
+		w := opts.ManifestWriter
+		if w == nil {
+			w = os.Stdout
+		}
+		manifestEnc = json.NewEncoder(w)
+	}
+
+	for i, sw := range sweepResources {
+		sem <- struct{}{}
+		wg.Add(1)
+
+		go func(i int, sw Sweepable) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			id := sw.Identifier()
+
+			var filterTags map[string]string
+			if opts.Filter != nil {
+				// Match may itself call sw.Tags(ctx), a real describe/get API
+				// call, when a tag filter is configured. Rate-limit that the
+				// same as the eventual delete so a -sweep-tag-filter run
+				// can't blow through the shared API budget before any
+				// resource is even deleted.
+				if opts.RateLimiter != nil && opts.Filter.HasTagConstraint() {
+					opts.RateLimiter.Wait(ctx)
+				}
+
+				match, tags, err := opts.Filter.Match(ctx, sw)
+				if err != nil {
+					mu.Lock()
+					results[i] = SweeperResult{Identifier: id, Error: err.Error()}
+					sweeperErrs = multierror.Append(sweeperErrs, err)
+					mu.Unlock()
+					return
+				}
+				if !match {
+					mu.Lock()
+					results[i] = SweeperResult{Identifier: id, Skipped: true}
+					mu.Unlock()
+					return
+				}
+				filterTags = tags
+			}
+
+			if opts.DryRun {
+				// Only include tags in the manifest if a tag filter was
+				// actually configured: Match() already fetched them to
+				// evaluate the filter, so reuse that result rather than
+				// calling sw.Tags(ctx) again for every candidate.
+				var tags map[string]string
+				if opts.Filter.HasTagConstraint() {
+					tags = filterTags
+				}
+
+				var arn string
+				if ap, ok := sw.(arnProvider); ok {
+					arn = ap.ARN()
+				}
+
+				mu.Lock()
+				if err := manifestEnc.Encode(ManifestEntry{
+					Identifier:   id,
+					ARN:          arn,
+					ResourceType: opts.ResourceType,
+					Region:       opts.Region,
+					Account:      opts.AccountID,
+					Tags:         tags,
+				}); err != nil {
+					log.Printf("[WARN] sweep: error writing manifest entry for %s: %s", id, err)
+				}
+				results[i] = SweeperResult{Identifier: id, DryRun: true}
+				mu.Unlock()
+				return
+			}
+
+			if opts.RateLimiter != nil {
+				opts.RateLimiter.Wait(ctx)
+			}
+
+			resStart := time.Now()
+			err := deleteWithBackoff(ctx, sw, defaultSweepTimeout)
+			dur := time.Since(resStart)
+
+			mu.Lock()
+			defer mu.Unlock()
+			results[i] = SweeperResult{Identifier: id, Duration: dur}
+			if err != nil {
+				results[i].Error = err.Error()
+				results[i].FailureClass = classifyFailure(err)
+				sweeperErrs = multierror.Append(sweeperErrs, err)
+			}
+		}(i, sw)
+	}
+	wg.Wait()
+
+	swept, skipped := 0, 0
+	for _, r := range results {
+		if r.Skipped {
+			skipped++
+		} else {
+			swept++
+		}
+	}
+	verb := "swept"
+	if opts.DryRun {
+		verb = "would sweep"
+	}
+	log.Printf("[INFO] sweep: %s %d, skipped %d (of %d)", verb, swept, skipped, len(results))
+
+	if opts.Report {
+		emitReport(opts.ReportWriter, start, results)
+	}
+
+	return sweeperErrs.ErrorOrNil()
+}
+
+func emitReport(w *os.File, start time.Time, results []SweeperResult) {
+	report := SweeperReport{
+		StartedAt: start,
+		Duration:  time.Since(start),
+		Total:     len(results),
+		Results:   results,
+	}
+	for _, r := range results {
+		switch {
+		case r.Skipped:
+			report.Skipped++
+		case r.Error == "":
+			report.Succeeded++
+		default:
+			report.Failed++
+		}
+	}
+
+	if w == nil {
+		w = os.Stdout
+	}
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		log.Printf("[WARN] error encoding sweep report: %s", err)
+	}
+}
+
+// deleteWithBackoff retries sw.Delete on throttling-shaped errors using a
+// jittered exponential backoff.
+func deleteWithBackoff(ctx context.Context, sw Sweepable, timeout time.Duration) error {
+	var err error
+	for attempt := 0; attempt < maxDeleteAttempts; attempt++ {
+		err = sw.Delete(ctx, timeout)
+		if err == nil || !isThrottlingError(err) {
+			return err
+		}
+
+		backoff := time.Duration(1<<attempt) * 100 * time.Millisecond
+		jitter := time.Duration(rand.Int63n(int64(backoff)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff + jitter):
+		}
+	}
+	return err
+}
+
+func isThrottlingError(err error) bool {
+	if err == nil {
+		return false
+	}
+	msg := err.Error()
+	return strings.Contains(msg, "ThrottlingException") || strings.Contains(msg, "SlowDown")
+}
+
+func classifyFailure(err error) string {
+	switch {
+	case err == nil:
+		return ""
+	case isThrottlingError(err):
+		return "throttling"
+	case strings.Contains(err.Error(), "NotFound"):
+		return "not_found"
+	default:
+		return "other"
+	}
+}
+
+// RateLimiter is a minimal token bucket shared across sweepers running
+// concurrently within the same region, so they stay within a shared API
+// budget (e.g. S3 Control's per-account request limits).
+type RateLimiter struct {
+	tokens chan struct{}
+	ticker *time.Ticker
+}
+
+// NewRateLimiter returns a RateLimiter that allows ratePerSecond operations
+// per second, shared across all callers of Wait.
+func NewRateLimiter(ratePerSecond int) *RateLimiter {
+	if ratePerSecond < 1 {
+		ratePerSecond = 1
+	}
+	rl := &RateLimiter{
+		tokens: make(chan struct{}, ratePerSecond),
+		ticker: time.NewTicker(time.Second / time.Duration(ratePerSecond)),
+	}
+	go func() {
+		for range rl.ticker.C {
+			select {
+			case rl.tokens <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return rl
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (rl *RateLimiter) Wait(ctx context.Context) {
+	select {
+	case <-rl.tokens:
+	case <-ctx.Done():
+	}
+}
+
+// Stop releases the RateLimiter's background ticker.
+func (rl *RateLimiter) Stop() {
+	rl.ticker.Stop()
+}
@@ -0,0 +1,120 @@
+// Copyright (c) HashiCorp, Inc.
+// SPDX-License-Identifier: MPL-2.0
+
+//go:build sweep
+// +build sweep
+
+package sweep
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-provider-aws/internal/tfresource"
+)
+
+// trackingSweepable records how many Delete calls are in flight at once, to
+// verify SweepOrchestratorWithContext honors WithMaxConcurrency, and how
+// many times its own Delete was called, to verify filtering.
+type trackingSweepable struct {
+	id string
+
+	current *int32
+	max     *int32
+	deletes int32
+}
+
+func (sw *trackingSweepable) Delete(ctx context.Context, timeout time.Duration, optFns ...tfresource.OptionsFunc) error {
+	atomic.AddInt32(&sw.deletes, 1)
+
+	n := atomic.AddInt32(sw.current, 1)
+	for {
+		m := atomic.LoadInt32(sw.max)
+		if n <= m || atomic.CompareAndSwapInt32(sw.max, m, n) {
+			break
+		}
+	}
+	time.Sleep(10 * time.Millisecond)
+	atomic.AddInt32(sw.current, -1)
+	return nil
+}
+
+func (sw *trackingSweepable) Identifier() string { return sw.id }
+
+func (sw *trackingSweepable) Tags(ctx context.Context) (map[string]string, error) { return nil, nil }
+
+func TestSweepOrchestratorWithContextBoundsConcurrency(t *testing.T) {
+	t.Parallel()
+
+	var current, max int32
+	sweepResources := make([]Sweepable, 0, 20)
+	for i := 0; i < 20; i++ {
+		sweepResources = append(sweepResources, &trackingSweepable{
+			id:      "resource",
+			current: &current,
+			max:     &max,
+		})
+	}
+
+	if err := SweepOrchestratorWithContext(context.Background(), sweepResources, WithMaxConcurrency(3)); err != nil {
+		t.Fatalf("SweepOrchestratorWithContext() returned unexpected error: %s", err)
+	}
+
+	if got := atomic.LoadInt32(&max); got > 3 {
+		t.Errorf("observed %d deletes in flight at once, want <= 3", got)
+	}
+}
+
+func TestSweepOrchestratorWithContextAppliesFilter(t *testing.T) {
+	t.Parallel()
+
+	var current, max int32
+	swept := &trackingSweepable{id: "keep-me", current: &current, max: &max}
+	skipped := &trackingSweepable{id: "drop-me", current: &current, max: &max}
+
+	filter, err := NewFilter("keep-*", "", "")
+	if err != nil {
+		t.Fatalf("NewFilter() returned unexpected error: %s", err)
+	}
+
+	err = SweepOrchestratorWithContext(context.Background(), []Sweepable{swept, skipped}, WithFilter(filter))
+	if err != nil {
+		t.Fatalf("SweepOrchestratorWithContext() returned unexpected error: %s", err)
+	}
+
+	if got := atomic.LoadInt32(&swept.deletes); got != 1 {
+		t.Errorf("matching resource %q: Delete called %d times, want 1", swept.id, got)
+	}
+	if got := atomic.LoadInt32(&skipped.deletes); got != 0 {
+		t.Errorf("non-matching resource %q: Delete called %d times, want 0", skipped.id, got)
+	}
+}
+
+func TestClassifyFailure(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		err  error
+		want string
+	}{
+		"nil error":        {err: nil, want: ""},
+		"throttling error": {err: errors.New("ThrottlingException: rate exceeded"), want: "throttling"},
+		"slow down error":  {err: errors.New("SlowDown: please reduce your request rate"), want: "throttling"},
+		"not found error":  {err: errors.New("NotFoundException: no such resource"), want: "not_found"},
+		"other error":      {err: errors.New("AccessDenied"), want: "other"},
+	}
+
+	for name, tt := range tests {
+		tt := tt
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := classifyFailure(tt.err); got != tt.want {
+				t.Errorf("classifyFailure(%v) = %q, want %q", tt.err, got, tt.want)
+			}
+		})
+	}
+}